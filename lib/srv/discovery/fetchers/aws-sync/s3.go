@@ -21,11 +21,13 @@ package aws_sync
 import (
 	"context"
 	"errors"
+	"slices"
 	"sync"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go/aws/awserr"
-	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
 	"github.com/gravitational/trace"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/protobuf/proto"
@@ -35,6 +37,64 @@ import (
 	awsutil "github.com/gravitational/teleport/lib/utils/aws"
 )
 
+// s3API is the subset of the S3 API used by the s3 bucket fetcher. It exists
+// so that region-scoped *s3.Client instances can be cached and swapped out
+// for a fake in tests.
+type s3API interface {
+	ListBuckets(ctx context.Context, params *s3.ListBucketsInput, optFns ...func(*s3.Options)) (*s3.ListBucketsOutput, error)
+	HeadBucket(ctx context.Context, params *s3.HeadBucketInput, optFns ...func(*s3.Options)) (*s3.HeadBucketOutput, error)
+	GetBucketLocation(ctx context.Context, params *s3.GetBucketLocationInput, optFns ...func(*s3.Options)) (*s3.GetBucketLocationOutput, error)
+	GetBucketPolicy(ctx context.Context, params *s3.GetBucketPolicyInput, optFns ...func(*s3.Options)) (*s3.GetBucketPolicyOutput, error)
+	GetBucketPolicyStatus(ctx context.Context, params *s3.GetBucketPolicyStatusInput, optFns ...func(*s3.Options)) (*s3.GetBucketPolicyStatusOutput, error)
+	GetBucketAcl(ctx context.Context, params *s3.GetBucketAclInput, optFns ...func(*s3.Options)) (*s3.GetBucketAclOutput, error)
+	GetBucketTagging(ctx context.Context, params *s3.GetBucketTaggingInput, optFns ...func(*s3.Options)) (*s3.GetBucketTaggingOutput, error)
+	GetPublicAccessBlock(ctx context.Context, params *s3.GetPublicAccessBlockInput, optFns ...func(*s3.Options)) (*s3.GetPublicAccessBlockOutput, error)
+	GetBucketEncryption(ctx context.Context, params *s3.GetBucketEncryptionInput, optFns ...func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error)
+	GetBucketVersioning(ctx context.Context, params *s3.GetBucketVersioningInput, optFns ...func(*s3.Options)) (*s3.GetBucketVersioningOutput, error)
+	GetBucketOwnershipControls(ctx context.Context, params *s3.GetBucketOwnershipControlsInput, optFns ...func(*s3.Options)) (*s3.GetBucketOwnershipControlsOutput, error)
+	GetObjectLockConfiguration(ctx context.Context, params *s3.GetObjectLockConfigurationInput, optFns ...func(*s3.Options)) (*s3.GetObjectLockConfigurationOutput, error)
+}
+
+// s3ClientCache caches region-scoped S3 clients for the duration of a single
+// poll so that every bucket in a given region shares a client (and, with it,
+// the client's connection pool and retryer) instead of paying for a brand
+// new client per bucket.
+type s3ClientCache struct {
+	mu      sync.Mutex
+	clients map[string]s3API
+	newFn   func(ctx context.Context, region string) (s3API, error)
+}
+
+func newS3ClientCache(newFn func(ctx context.Context, region string) (s3API, error)) *s3ClientCache {
+	return &s3ClientCache{
+		clients: make(map[string]s3API),
+		newFn:   newFn,
+	}
+}
+
+func (c *s3ClientCache) get(ctx context.Context, region string) (s3API, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if client, ok := c.clients[region]; ok {
+		return client, nil
+	}
+	client, err := c.newFn(ctx, region)
+	if err != nil {
+		return nil, trace.Wrap(err)
+	}
+	c.clients[region] = client
+	return client, nil
+}
+
+// newS3ClientCache builds a client cache backed by CloudClients.GetAWSS3Client,
+// which this migration updates to return an aws-sdk-go-v2 *s3.Client (see the
+// lib/cloud companion change) satisfying s3API above.
+func (a *awsFetcher) newS3ClientCache() *s3ClientCache {
+	return newS3ClientCache(func(ctx context.Context, region string) (s3API, error) {
+		return a.CloudClients.GetAWSS3Client(ctx, region, a.getAWSOptions()...)
+	})
+}
+
 // pollAWSS3Buckets is a function that returns a function that fetches
 // AWS s3 buckets and their inline and attached policies.
 func (a *awsFetcher) pollAWSS3Buckets(ctx context.Context, result *Resources, collectErr func(error)) func() error {
@@ -71,7 +131,8 @@ func (a *awsFetcher) fetchS3Buckets(ctx context.Context) ([]*accessgraphv1alpha.
 		}
 	}
 
-	buckets, getBucketRegion, err := a.listS3Buckets(ctx)
+	cache := a.newS3ClientCache()
+	buckets, getBucketRegion, err := a.listS3Buckets(ctx, cache)
 	if err != nil {
 		return existing.S3Buckets, trace.Wrap(err)
 	}
@@ -86,23 +147,41 @@ func (a *awsFetcher) fetchS3Buckets(ctx context.Context) ([]*accessgraphv1alpha.
 				return b.Name == aws.ToString(bucket.Name) && b.AccountId == a.AccountID
 			},
 			)
-			bucketRegion, err := getBucketRegion(bucket.Name)
+			bucketRegion, err := getBucketRegion(ctx, bucket.Name)
 			if err != nil {
 				errs = append(errs,
 					trace.Wrap(err),
 				)
-				failedReqs.policyFailed = true
-				failedReqs.failedPolicyStatus = true
-				failedReqs.failedAcls = true
-				failedReqs.failedTags = true
-				newBucket := awsS3Bucket(aws.ToString(bucket.Name), nil, nil, nil, nil, a.AccountID)
+				// None of the per-bucket details were fetched, so mark every
+				// flag as failed. mergeS3Protos then falls back to whatever
+				// was last synced instead of wiping it out with the empty
+				// s3Details{} below.
+				failedReqs = failedRequests{
+					headFailed:              true,
+					policyFailed:            true,
+					failedPolicyStatus:      true,
+					failedAcls:              true,
+					failedTags:              true,
+					failedPublicAccessBlock: true,
+					failedEncryption:        true,
+					failedVersioning:        true,
+					failedOwnershipControls: true,
+					failedObjectLock:        true,
+				}
+				newBucket := awsS3Bucket(aws.ToString(bucket.Name), s3Details{}, a.AccountID)
 				collect(mergeS3Protos(existingBucket, newBucket, failedReqs), trace.NewAggregate(errs...))
 				return nil
 			}
 
-			details, failedReqs, errsL := a.getS3BucketDetails(ctx, bucket, bucketRegion)
+			// Skip buckets outside the configured region allowlist before
+			// issuing any per-bucket detail requests.
+			if !bucketRegionAllowed(a.Regions, bucketRegion) {
+				return nil
+			}
 
-			newBucket := awsS3Bucket(aws.ToString(bucket.Name), details.policy, details.policyStatus, details.acls, details.tags, a.AccountID)
+			details, failedReqs, errsL := a.getS3BucketDetails(ctx, cache, bucket, bucketRegion)
+
+			newBucket := awsS3Bucket(aws.ToString(bucket.Name), details, a.AccountID)
 			collect(mergeS3Protos(existingBucket, newBucket, failedReqs), trace.NewAggregate(append(errs, errsL...)...))
 			return nil
 		})
@@ -113,28 +192,27 @@ func (a *awsFetcher) fetchS3Buckets(ctx context.Context) ([]*accessgraphv1alpha.
 	return s3s, trace.NewAggregate(errs...)
 }
 
-func awsS3Bucket(name string,
-	policy *s3.GetBucketPolicyOutput,
-	policyStatus *s3.GetBucketPolicyStatusOutput,
-	acls *s3.GetBucketAclOutput,
-	tags *s3.GetBucketTaggingOutput,
-	accountID string,
-) *accessgraphv1alpha.AWSS3BucketV1 {
+// awsS3Bucket converts a bucket's fetched details into the wire type synced to
+// the access graph. PublicAccessBlockConfiguration,
+// ServerSideEncryptionConfiguration, VersioningConfiguration, OwnershipControls, and ObjectLockConfiguration are
+// populated from fields added to AWSS3BucketV1 in a companion api/proto
+// change; this fetcher assumes that change is present.
+func awsS3Bucket(name string, details s3Details, accountID string) *accessgraphv1alpha.AWSS3BucketV1 {
 	s3 := &accessgraphv1alpha.AWSS3BucketV1{
 		Name:         name,
 		AccountId:    accountID,
 		LastSyncTime: timestamppb.Now(),
 	}
-	if policy != nil {
+	if policy := details.policy; policy != nil {
 		s3.PolicyDocument = []byte(aws.ToString(policy.Policy))
 	}
-	if policyStatus != nil && policyStatus.PolicyStatus != nil {
+	if policyStatus := details.policyStatus; policyStatus != nil && policyStatus.PolicyStatus != nil {
 		s3.IsPublic = aws.ToBool(policyStatus.PolicyStatus.IsPublic)
 	}
-	if acls != nil {
+	if acls := details.acls; acls != nil {
 		s3.Acls = awsACLsToProtoACLs(acls.Grants)
 	}
-	if tags != nil {
+	if tags := details.tags; tags != nil {
 		for _, tag := range tags.TagSet {
 			s3.Tags = append(s3.Tags, &accessgraphv1alpha.AWSTag{
 				Key:   aws.ToString(tag.Key),
@@ -142,32 +220,82 @@ func awsS3Bucket(name string,
 			})
 		}
 	}
+	if pab := details.publicAccessBlock; pab != nil && pab.PublicAccessBlockConfiguration != nil {
+		conf := pab.PublicAccessBlockConfiguration
+		s3.PublicAccessBlockConfiguration = &accessgraphv1alpha.AWSS3PublicAccessBlockConfiguration{
+			BlockPublicAcls:       aws.ToBool(conf.BlockPublicAcls),
+			IgnorePublicAcls:      aws.ToBool(conf.IgnorePublicAcls),
+			BlockPublicPolicy:     aws.ToBool(conf.BlockPublicPolicy),
+			RestrictPublicBuckets: aws.ToBool(conf.RestrictPublicBuckets),
+		}
+	}
+	if enc := details.encryption; enc != nil && enc.ServerSideEncryptionConfiguration != nil {
+		s3.ServerSideEncryptionConfiguration = awsS3EncryptionToProto(enc.ServerSideEncryptionConfiguration.Rules)
+	}
+	if versioning := details.versioning; versioning != nil {
+		s3.VersioningConfiguration = &accessgraphv1alpha.AWSS3BucketVersioningConfiguration{
+			Status:    string(versioning.Status),
+			MfaDelete: string(versioning.MFADelete),
+		}
+	}
+	if ownership := details.ownershipControls; ownership != nil && ownership.OwnershipControls != nil {
+		for _, rule := range ownership.OwnershipControls.Rules {
+			s3.OwnershipControls = append(s3.OwnershipControls, &accessgraphv1alpha.AWSS3BucketOwnershipControl{
+				ObjectOwnership: string(rule.ObjectOwnership),
+			})
+		}
+	}
+	if lock := details.objectLock; lock != nil && lock.ObjectLockConfiguration != nil {
+		s3.ObjectLockConfiguration = &accessgraphv1alpha.AWSS3ObjectLockConfiguration{
+			ObjectLockEnabled: string(lock.ObjectLockConfiguration.ObjectLockEnabled),
+		}
+	}
 	return s3
 }
 
-func awsACLsToProtoACLs(grants []*s3.Grant) []*accessgraphv1alpha.AWSS3BucketACL {
+func awsS3EncryptionToProto(rules []types.ServerSideEncryptionRule) []*accessgraphv1alpha.AWSS3ServerSideEncryptionRule {
+	var protoRules []*accessgraphv1alpha.AWSS3ServerSideEncryptionRule
+	for _, rule := range rules {
+		protoRule := &accessgraphv1alpha.AWSS3ServerSideEncryptionRule{
+			BucketKeyEnabled: aws.ToBool(rule.BucketKeyEnabled),
+		}
+		if by := rule.ApplyServerSideEncryptionByDefault; by != nil {
+			protoRule.SseAlgorithm = string(by.SSEAlgorithm)
+			protoRule.KmsMasterKeyArn = aws.ToString(by.KMSMasterKeyID)
+		}
+		protoRules = append(protoRules, protoRule)
+	}
+	return protoRules
+}
+
+func awsACLsToProtoACLs(grants []types.Grant) []*accessgraphv1alpha.AWSS3BucketACL {
 	var acls []*accessgraphv1alpha.AWSS3BucketACL
 	for _, grant := range grants {
 		acls = append(acls, &accessgraphv1alpha.AWSS3BucketACL{
 			Grantee: &accessgraphv1alpha.AWSS3BucketACLGrantee{
 				Id:           aws.ToString(grant.Grantee.ID),
 				DisplayName:  aws.ToString(grant.Grantee.DisplayName),
-				Type:         aws.ToString(grant.Grantee.Type),
+				Type:         string(grant.Grantee.Type),
 				Uri:          aws.ToString(grant.Grantee.URI),
 				EmailAddress: aws.ToString(grant.Grantee.EmailAddress),
 			},
-			Permission: aws.ToString(grant.Permission),
+			Permission: string(grant.Permission),
 		})
 	}
 	return acls
 }
 
 type failedRequests struct {
-	policyFailed       bool
-	failedPolicyStatus bool
-	failedAcls         bool
-	failedTags         bool
-	headFailed         bool
+	policyFailed            bool
+	failedPolicyStatus      bool
+	failedAcls              bool
+	failedTags              bool
+	headFailed              bool
+	failedPublicAccessBlock bool
+	failedEncryption        bool
+	failedVersioning        bool
+	failedOwnershipControls bool
+	failedObjectLock        bool
 }
 
 func mergeS3Protos(existing, new *accessgraphv1alpha.AWSS3BucketV1, failedReqs failedRequests) *accessgraphv1alpha.AWSS3BucketV1 {
@@ -190,127 +318,245 @@ func mergeS3Protos(existing, new *accessgraphv1alpha.AWSS3BucketV1, failedReqs f
 	if failedReqs.failedTags {
 		clone.Tags = existing.Tags
 	}
+	if failedReqs.failedPublicAccessBlock {
+		clone.PublicAccessBlockConfiguration = existing.PublicAccessBlockConfiguration
+	}
+	if failedReqs.failedEncryption {
+		clone.ServerSideEncryptionConfiguration = existing.ServerSideEncryptionConfiguration
+	}
+	if failedReqs.failedVersioning {
+		clone.VersioningConfiguration = existing.VersioningConfiguration
+	}
+	if failedReqs.failedOwnershipControls {
+		clone.OwnershipControls = existing.OwnershipControls
+	}
+	if failedReqs.failedObjectLock {
+		clone.ObjectLockConfiguration = existing.ObjectLockConfiguration
+	}
 
 	return clone
 }
 
 type s3Details struct {
-	policy       *s3.GetBucketPolicyOutput
-	policyStatus *s3.GetBucketPolicyStatusOutput
-	acls         *s3.GetBucketAclOutput
-	tags         *s3.GetBucketTaggingOutput
+	policy            *s3.GetBucketPolicyOutput
+	policyStatus      *s3.GetBucketPolicyStatusOutput
+	acls              *s3.GetBucketAclOutput
+	tags              *s3.GetBucketTaggingOutput
+	publicAccessBlock *s3.GetPublicAccessBlockOutput
+	encryption        *s3.GetBucketEncryptionOutput
+	versioning        *s3.GetBucketVersioningOutput
+	ownershipControls *s3.GetBucketOwnershipControlsOutput
+	objectLock        *s3.GetObjectLockConfigurationOutput
 }
 
-func (a *awsFetcher) getS3BucketDetails(ctx context.Context, bucket *s3.Bucket, bucketRegion string) (s3Details, failedRequests, []error) {
-	var failedReqs failedRequests
-	var errs []error
+func (a *awsFetcher) getS3BucketDetails(ctx context.Context, cache *s3ClientCache, bucket types.Bucket, bucketRegion string) (s3Details, failedRequests, []error) {
 	var details s3Details
 
-	s3Client, err := a.CloudClients.GetAWSS3Client(
-		ctx,
-		bucketRegion,
-		a.getAWSOptions()...,
-	)
+	s3Client, err := cache.get(ctx, bucketRegion)
 	if err != nil {
-		errs = append(errs,
-			trace.Wrap(err, "failed to create s3 client for bucket %q", aws.ToString(bucket.Name)),
-		)
 		return s3Details{},
 			failedRequests{
-				headFailed:         true,
-				policyFailed:       true,
-				failedPolicyStatus: true,
-				failedAcls:         true,
-				failedTags:         true,
-			}, errs
+				headFailed:              true,
+				policyFailed:            true,
+				failedPolicyStatus:      true,
+				failedAcls:              true,
+				failedTags:              true,
+				failedPublicAccessBlock: true,
+				failedEncryption:        true,
+				failedVersioning:        true,
+				failedOwnershipControls: true,
+				failedObjectLock:        true,
+			},
+			[]error{trace.Wrap(err, "failed to create s3 client for bucket %q", aws.ToString(bucket.Name))}
 	}
 
-	details.policy, err = s3Client.GetBucketPolicyWithContext(ctx, &s3.GetBucketPolicyInput{
-		Bucket: bucket.Name,
-	})
-	if err != nil && !isS3BucketPolicyNotFound(err) {
-		errs = append(errs,
-			trace.Wrap(err, "failed to fetch bucket %q inline policy", aws.ToString(bucket.Name)),
-		)
-		failedReqs.policyFailed = true
+	var mu sync.Mutex
+	var failedReqs failedRequests
+	var errs []error
+	fail := func(flag *bool, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		*flag = true
+		errs = append(errs, err)
 	}
 
-	details.policyStatus, err = s3Client.GetBucketPolicyStatusWithContext(ctx, &s3.GetBucketPolicyStatusInput{
-		Bucket: bucket.Name,
+	eG, ctx := errgroup.WithContext(ctx)
+	eG.Go(func() error {
+		var err error
+		details.policy, err = s3Client.GetBucketPolicy(ctx, &s3.GetBucketPolicyInput{Bucket: bucket.Name})
+		if err != nil && !isS3BucketPolicyNotFound(err) {
+			fail(&failedReqs.policyFailed, trace.Wrap(err, "failed to fetch bucket %q inline policy", aws.ToString(bucket.Name)))
+		}
+		return nil
 	})
-	if err != nil && !isS3BucketPolicyNotFound(err) {
-		errs = append(errs,
-			trace.Wrap(err, "failed to fetch bucket %q policy status", aws.ToString(bucket.Name)),
-		)
-		failedReqs.failedPolicyStatus = true
-	}
-
-	details.acls, err = s3Client.GetBucketAclWithContext(ctx, &s3.GetBucketAclInput{
-		Bucket: bucket.Name,
+	eG.Go(func() error {
+		var err error
+		details.policyStatus, err = s3Client.GetBucketPolicyStatus(ctx, &s3.GetBucketPolicyStatusInput{Bucket: bucket.Name})
+		if err != nil && !isS3BucketPolicyNotFound(err) {
+			fail(&failedReqs.failedPolicyStatus, trace.Wrap(err, "failed to fetch bucket %q policy status", aws.ToString(bucket.Name)))
+		}
+		return nil
 	})
-	if err != nil {
-		errs = append(errs,
-			trace.Wrap(err, "failed to fetch bucket %q acls policies", aws.ToString(bucket.Name)),
-		)
-		failedReqs.failedAcls = true
-	}
-
-	details.tags, err = s3Client.GetBucketTaggingWithContext(ctx, &s3.GetBucketTaggingInput{
-		Bucket: bucket.Name,
+	eG.Go(func() error {
+		var err error
+		details.acls, err = s3Client.GetBucketAcl(ctx, &s3.GetBucketAclInput{Bucket: bucket.Name})
+		if err != nil {
+			fail(&failedReqs.failedAcls, trace.Wrap(err, "failed to fetch bucket %q acls policies", aws.ToString(bucket.Name)))
+		}
+		return nil
 	})
-	if err != nil && !isS3BucketNoTagSet(err) {
-		errs = append(errs,
-			trace.Wrap(err, "failed to fetch bucket %q tags", aws.ToString(bucket.Name)),
-		)
-		failedReqs.failedTags = true
-	}
+	eG.Go(func() error {
+		var err error
+		details.tags, err = s3Client.GetBucketTagging(ctx, &s3.GetBucketTaggingInput{Bucket: bucket.Name})
+		if err != nil && !isS3BucketNoTagSet(err) {
+			fail(&failedReqs.failedTags, trace.Wrap(err, "failed to fetch bucket %q tags", aws.ToString(bucket.Name)))
+		}
+		return nil
+	})
+	eG.Go(func() error {
+		var err error
+		details.publicAccessBlock, err = s3Client.GetPublicAccessBlock(ctx, &s3.GetPublicAccessBlockInput{Bucket: bucket.Name})
+		if err != nil && !isS3PublicAccessBlockNotFound(err) {
+			fail(&failedReqs.failedPublicAccessBlock, trace.Wrap(err, "failed to fetch bucket %q public access block", aws.ToString(bucket.Name)))
+		}
+		return nil
+	})
+	eG.Go(func() error {
+		var err error
+		details.encryption, err = s3Client.GetBucketEncryption(ctx, &s3.GetBucketEncryptionInput{Bucket: bucket.Name})
+		if err != nil && !isS3BucketEncryptionNotFound(err) {
+			fail(&failedReqs.failedEncryption, trace.Wrap(err, "failed to fetch bucket %q encryption", aws.ToString(bucket.Name)))
+		}
+		return nil
+	})
+	eG.Go(func() error {
+		var err error
+		details.versioning, err = s3Client.GetBucketVersioning(ctx, &s3.GetBucketVersioningInput{Bucket: bucket.Name})
+		if err != nil {
+			fail(&failedReqs.failedVersioning, trace.Wrap(err, "failed to fetch bucket %q versioning", aws.ToString(bucket.Name)))
+		}
+		return nil
+	})
+	eG.Go(func() error {
+		var err error
+		details.ownershipControls, err = s3Client.GetBucketOwnershipControls(ctx, &s3.GetBucketOwnershipControlsInput{Bucket: bucket.Name})
+		if err != nil && !isS3OwnershipControlsNotFound(err) {
+			fail(&failedReqs.failedOwnershipControls, trace.Wrap(err, "failed to fetch bucket %q ownership controls", aws.ToString(bucket.Name)))
+		}
+		return nil
+	})
+	eG.Go(func() error {
+		var err error
+		details.objectLock, err = s3Client.GetObjectLockConfiguration(ctx, &s3.GetObjectLockConfigurationInput{Bucket: bucket.Name})
+		if err != nil && !isS3ObjectLockConfigurationNotFound(err) {
+			fail(&failedReqs.failedObjectLock, trace.Wrap(err, "failed to fetch bucket %q object lock configuration", aws.ToString(bucket.Name)))
+		}
+		return nil
+	})
+	// always discard the error; failures are tracked per-request in failedReqs.
+	_ = eG.Wait()
 
 	return details, failedReqs, errs
 }
 
+// isS3BucketPolicyNotFound returns true if err is the NoSuchBucketPolicy error
+// returned by GetBucketPolicy/GetBucketPolicyStatus for a bucket that doesn't
+// have a policy attached. This error isn't modeled by the S3 API, so it's
+// matched by code instead of by type.
 func isS3BucketPolicyNotFound(err error) bool {
-	var awsErr awserr.Error
-	return errors.As(err, &awsErr) && awsErr.Code() == "NoSuchBucketPolicy"
+	return isS3ErrorCode(err, "NoSuchBucketPolicy")
 }
 
+// isS3BucketNoTagSet returns true if err is the NoSuchTagSet error returned by
+// GetBucketTagging for a bucket without any tags. This error isn't modeled by
+// the S3 API, so it's matched by code instead of by type.
 func isS3BucketNoTagSet(err error) bool {
-	var awsErr awserr.Error
-	return errors.As(err, &awsErr) && awsErr.Code() == "NoSuchTagSet"
+	return isS3ErrorCode(err, "NoSuchTagSet")
+}
+
+// isS3PublicAccessBlockNotFound returns true if err is the
+// NoSuchPublicAccessBlockConfiguration error returned by GetPublicAccessBlock
+// for a bucket that doesn't have a Public Access Block configured. This error
+// isn't modeled by the S3 API, so it's matched by code instead of by type.
+func isS3PublicAccessBlockNotFound(err error) bool {
+	return isS3ErrorCode(err, "NoSuchPublicAccessBlockConfiguration")
+}
+
+// isS3BucketEncryptionNotFound returns true if err is the
+// ServerSideEncryptionConfigurationNotFoundError returned by
+// GetBucketEncryption for a bucket without default encryption configured.
+func isS3BucketEncryptionNotFound(err error) bool {
+	return isS3ErrorCode(err, "ServerSideEncryptionConfigurationNotFoundError")
+}
+
+// isS3OwnershipControlsNotFound returns true if err is the
+// OwnershipControlsNotFoundError returned by GetBucketOwnershipControls for a
+// bucket without ownership controls configured.
+func isS3OwnershipControlsNotFound(err error) bool {
+	return isS3ErrorCode(err, "OwnershipControlsNotFoundError")
+}
+
+// isS3ObjectLockConfigurationNotFound returns true if err is the
+// ObjectLockConfigurationNotFoundError returned by
+// GetObjectLockConfiguration for a bucket without object lock configured.
+func isS3ObjectLockConfigurationNotFound(err error) bool {
+	return isS3ErrorCode(err, "ObjectLockConfigurationNotFoundError")
+}
+
+// isS3ErrorCode returns true if err is an S3 API error with the given code.
+// It's used for errors that aren't modeled as distinct types by the S3 API.
+func isS3ErrorCode(err error, code string) bool {
+	var apiErr smithy.APIError
+	return errors.As(err, &apiErr) && apiErr.ErrorCode() == code
 }
 
-func (a *awsFetcher) listS3Buckets(ctx context.Context) ([]*s3.Bucket, func(*string) (string, error), error) {
+func (a *awsFetcher) listS3Buckets(ctx context.Context, cache *s3ClientCache) ([]types.Bucket, func(context.Context, *string) (string, error), error) {
 	region := awsutil.GetKnownRegions()[0]
 	if len(a.Regions) > 0 {
 		region = a.Regions[0]
 	}
 
 	// use any region to list buckets
-	s3Client, err := a.CloudClients.GetAWSS3Client(
-		ctx,
-		region,
-		a.getAWSOptions()...,
-	)
+	s3Client, err := cache.get(ctx, region)
 	if err != nil {
 		return nil, nil, trace.Wrap(err)
 	}
-	rsp, err := s3Client.ListBucketsWithContext(ctx, &s3.ListBucketsInput{})
+	rsp, err := s3Client.ListBuckets(ctx, &s3.ListBucketsInput{})
 	if err != nil {
 		return nil, nil, trace.Wrap(err)
 	}
 	return rsp.Buckets,
-		func(bucket *string) (string, error) {
-			rsp, err := s3Client.GetBucketLocationWithContext(
-				ctx,
-				&s3.GetBucketLocationInput{
-					Bucket: bucket,
-				},
-			)
-			if err != nil {
-				return "", trace.Wrap(err, "failed to fetch bucket %q region", aws.ToString(bucket))
-			}
-			if rsp.LocationConstraint == nil {
-				return "us-east-1", nil
-			}
-			return aws.ToString(rsp.LocationConstraint), nil
+		func(ctx context.Context, bucket *string) (string, error) {
+			return getBucketRegion(ctx, s3Client, bucket)
 		}, nil
 }
+
+// bucketRegionAllowed reports whether bucketRegion should be fetched, given
+// the fetcher's region allowlist. An empty allowlist allows every region.
+func bucketRegionAllowed(regions []string, bucketRegion string) bool {
+	return len(regions) == 0 || slices.Contains(regions, bucketRegion)
+}
+
+// getBucketRegion resolves a bucket's region from the x-amz-bucket-region
+// header on a successful HeadBucket response. It falls back to
+// GetBucketLocation (against the same client) if HeadBucket fails, for
+// example with a 301 or 403 status, or if its response omits the header; the
+// header of a failed HeadBucket response is not read.
+func getBucketRegion(ctx context.Context, client s3API, bucket *string) (string, error) {
+	headRsp, headErr := client.HeadBucket(ctx, &s3.HeadBucketInput{Bucket: bucket})
+	if headErr == nil && aws.ToString(headRsp.BucketRegion) != "" {
+		return aws.ToString(headRsp.BucketRegion), nil
+	}
+
+	locRsp, locErr := client.GetBucketLocation(ctx, &s3.GetBucketLocationInput{Bucket: bucket})
+	if locErr != nil {
+		if headErr != nil {
+			return "", trace.Wrap(headErr, "failed to fetch bucket %q region", aws.ToString(bucket))
+		}
+		return "", trace.Wrap(locErr, "failed to fetch bucket %q region", aws.ToString(bucket))
+	}
+	if locRsp.LocationConstraint == "" {
+		return "us-east-1", nil
+	}
+	return string(locRsp.LocationConstraint), nil
+}
@@ -0,0 +1,126 @@
+/*
+ * Teleport
+ * Copyright (C) 2024  Gravitational, Inc.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package aws_sync
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/smithy-go"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeS3API implements s3API for the handful of methods exercised by
+// getBucketRegion; every other method panics if called, so tests fail loudly
+// if a code path starts depending on something unexpected.
+type fakeS3API struct {
+	s3API
+
+	headBucketOutput *s3.HeadBucketOutput
+	headBucketErr    error
+
+	getBucketLocationOutput *s3.GetBucketLocationOutput
+	getBucketLocationErr    error
+}
+
+func (f *fakeS3API) HeadBucket(context.Context, *s3.HeadBucketInput, ...func(*s3.Options)) (*s3.HeadBucketOutput, error) {
+	return f.headBucketOutput, f.headBucketErr
+}
+
+func (f *fakeS3API) GetBucketLocation(context.Context, *s3.GetBucketLocationInput, ...func(*s3.Options)) (*s3.GetBucketLocationOutput, error) {
+	return f.getBucketLocationOutput, f.getBucketLocationErr
+}
+
+type fakeAPIError struct {
+	code string
+}
+
+func (e *fakeAPIError) Error() string                 { return e.code }
+func (e *fakeAPIError) ErrorCode() string             { return e.code }
+func (e *fakeAPIError) ErrorMessage() string          { return e.code }
+func (e *fakeAPIError) ErrorFault() smithy.ErrorFault { return smithy.FaultUnknown }
+
+func TestGetBucketRegion(t *testing.T) {
+	bucket := aws.String("my-bucket")
+
+	t.Run("uses the HeadBucket region header", func(t *testing.T) {
+		client := &fakeS3API{
+			headBucketOutput: &s3.HeadBucketOutput{BucketRegion: aws.String("eu-west-1")},
+		}
+		region, err := getBucketRegion(context.Background(), client, bucket)
+		require.NoError(t, err)
+		require.Equal(t, "eu-west-1", region)
+	})
+
+	t.Run("falls back to GetBucketLocation on HeadBucket error without a header", func(t *testing.T) {
+		client := &fakeS3API{
+			headBucketErr:           &fakeAPIError{code: "Forbidden"},
+			getBucketLocationOutput: &s3.GetBucketLocationOutput{LocationConstraint: "ap-south-1"},
+		}
+		region, err := getBucketRegion(context.Background(), client, bucket)
+		require.NoError(t, err)
+		require.Equal(t, "ap-south-1", region)
+	})
+
+	t.Run("falls back to GetBucketLocation on HeadBucket response with no header", func(t *testing.T) {
+		client := &fakeS3API{
+			headBucketOutput:        &s3.HeadBucketOutput{},
+			getBucketLocationOutput: &s3.GetBucketLocationOutput{LocationConstraint: ""},
+		}
+		region, err := getBucketRegion(context.Background(), client, bucket)
+		require.NoError(t, err)
+		require.Equal(t, "us-east-1", region)
+	})
+
+	t.Run("returns an error when both calls fail", func(t *testing.T) {
+		client := &fakeS3API{
+			headBucketErr:        &fakeAPIError{code: "Forbidden"},
+			getBucketLocationErr: &fakeAPIError{code: "AccessDenied"},
+		}
+		_, err := getBucketRegion(context.Background(), client, bucket)
+		require.Error(t, err)
+	})
+}
+
+func TestS3ClientCacheReusesClientsPerRegion(t *testing.T) {
+	var calls int
+	cache := newS3ClientCache(func(_ context.Context, region string) (s3API, error) {
+		calls++
+		return &fakeS3API{}, nil
+	})
+
+	client1, err := cache.get(context.Background(), "us-east-1")
+	require.NoError(t, err)
+	client2, err := cache.get(context.Background(), "us-east-1")
+	require.NoError(t, err)
+	require.Same(t, client1, client2)
+	require.Equal(t, 1, calls)
+
+	_, err = cache.get(context.Background(), "eu-west-1")
+	require.NoError(t, err)
+	require.Equal(t, 2, calls)
+}
+
+func TestBucketRegionAllowed(t *testing.T) {
+	require.True(t, bucketRegionAllowed(nil, "us-east-1"))
+	require.True(t, bucketRegionAllowed([]string{"us-east-1", "eu-west-1"}, "eu-west-1"))
+	require.False(t, bucketRegionAllowed([]string{"us-east-1"}, "eu-west-1"))
+}
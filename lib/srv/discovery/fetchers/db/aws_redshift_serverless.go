@@ -21,10 +21,11 @@ package db
 import (
 	"context"
 	"log/slog"
+	"strings"
 
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/service/redshiftserverless"
-	"github.com/aws/aws-sdk-go/service/redshiftserverless/redshiftserverlessiface"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/redshiftserverless"
+	rsstypes "github.com/aws/aws-sdk-go-v2/service/redshiftserverless/types"
 	"github.com/gravitational/trace"
 
 	"github.com/gravitational/teleport/api/types"
@@ -40,9 +41,9 @@ func newRedshiftServerlessFetcher(cfg awsFetcherConfig) (common.Fetcher, error)
 }
 
 type workgroupWithTags struct {
-	*redshiftserverless.Workgroup
+	rsstypes.Workgroup
 
-	Tags []*redshiftserverless.Tag
+	Tags []rsstypes.Tag
 }
 
 // redshiftServerlessPlugin retrieves Redshift Serverless databases.
@@ -53,8 +54,24 @@ func (f *redshiftServerlessPlugin) ComponentShortName() string {
 	return "rss<"
 }
 
-// rssAPI is a type alias for brevity alone.
-type rssAPI = redshiftserverlessiface.RedshiftServerlessAPI
+// rssClient is the subset of the Redshift Serverless API used by this
+// fetcher.
+type rssClient interface {
+	ListWorkgroups(ctx context.Context, params *redshiftserverless.ListWorkgroupsInput, optFns ...func(*redshiftserverless.Options)) (*redshiftserverless.ListWorkgroupsOutput, error)
+	ListEndpointAccess(ctx context.Context, params *redshiftserverless.ListEndpointAccessInput, optFns ...func(*redshiftserverless.Options)) (*redshiftserverless.ListEndpointAccessOutput, error)
+	ListTagsForResource(ctx context.Context, params *redshiftserverless.ListTagsForResourceInput, optFns ...func(*redshiftserverless.Options)) (*redshiftserverless.ListTagsForResourceOutput, error)
+	ListNamespaces(ctx context.Context, params *redshiftserverless.ListNamespacesInput, optFns ...func(*redshiftserverless.Options)) (*redshiftserverless.ListNamespacesOutput, error)
+	ListSnapshots(ctx context.Context, params *redshiftserverless.ListSnapshotsInput, optFns ...func(*redshiftserverless.Options)) (*redshiftserverless.ListSnapshotsOutput, error)
+	ListUsageLimits(ctx context.Context, params *redshiftserverless.ListUsageLimitsInput, optFns ...func(*redshiftserverless.Options)) (*redshiftserverless.ListUsageLimitsOutput, error)
+}
+
+// redshiftServerlessLabelSnapshots is the discovery label holding the
+// recovery-point/snapshot names taken of a workgroup's namespace.
+const redshiftServerlessLabelSnapshots = "aws-redshift-serverless-snapshots"
+
+// redshiftServerlessLabelUsageLimits is the discovery label holding the
+// usage limit IDs configured for a workgroup.
+const redshiftServerlessLabelUsageLimits = "aws-redshift-serverless-usage-limits"
 
 // GetDatabases returns Redshift Serverless databases matching the watcher's selectors.
 func (f *redshiftServerlessPlugin) GetDatabases(ctx context.Context, cfg *awsFetcherConfig) (types.Databases, error) {
@@ -65,11 +82,16 @@ func (f *redshiftServerlessPlugin) GetDatabases(ctx context.Context, cfg *awsFet
 	if err != nil {
 		return nil, trace.Wrap(err)
 	}
-	databases, workgroups, err := getDatabasesFromWorkgroups(ctx, client, cfg.Logger)
+
+	namespaces := getRSSNamespacesByName(ctx, client, cfg.Logger)
+	databases, workgroups, err := getDatabasesFromWorkgroups(ctx, client, namespaces, cfg.Logger)
 	if err != nil {
 		return nil, trace.Wrap(err)
 	}
 
+	applyRSSSnapshotLabels(ctx, client, databases, workgroups, cfg.Logger)
+	applyRSSUsageLimitLabels(ctx, client, databases, workgroups, cfg.Logger)
+
 	if len(workgroups) > 0 {
 		vpcEndpointDatabases, err := getDatabasesFromVPCEndpoints(ctx, workgroups, client, cfg.Logger)
 		if err != nil {
@@ -85,7 +107,16 @@ func (f *redshiftServerlessPlugin) GetDatabases(ctx context.Context, cfg *awsFet
 	return databases, nil
 }
 
-func getDatabasesFromWorkgroups(ctx context.Context, client rssAPI, logger *slog.Logger) (types.Databases, []*workgroupWithTags, error) {
+// getDatabasesFromWorkgroups converts each available workgroup into a
+// database. When the workgroup's namespace was found, it's passed to
+// common.NewDatabaseFromRedshiftServerlessWorkgroupWithNamespace (a companion
+// addition to the common package) so the namespace's IAM roles and KMS key
+// are attached to the database; otherwise it falls back to
+// NewDatabaseFromRedshiftServerlessWorkgroup. Both constructors name the
+// database after the workgroup, which applyRSSSnapshotLabels and
+// applyRSSUsageLimitLabels below rely on to join databases back to workgroups
+// by WorkgroupName.
+func getDatabasesFromWorkgroups(ctx context.Context, client rssClient, namespaces map[string]rsstypes.Namespace, logger *slog.Logger) (types.Databases, []*workgroupWithTags, error) {
 	workgroups, err := getRSSWorkgroups(ctx, client)
 	if err != nil {
 		return nil, nil, trace.Wrap(err)
@@ -94,19 +125,25 @@ func getDatabasesFromWorkgroups(ctx context.Context, client rssAPI, logger *slog
 	var databases types.Databases
 	var workgroupsWithTags []*workgroupWithTags
 	for _, workgroup := range workgroups {
-		if !libcloudaws.IsResourceAvailable(workgroup, workgroup.Status) {
+		if !libcloudaws.IsResourceAvailable(workgroup, string(workgroup.Status)) {
 			logger.DebugContext(ctx, "Skipping unavailable  Redshift Serverless workgroup",
-				"workgroup", aws.StringValue(workgroup.WorkgroupName),
-				"status", aws.StringValue(workgroup.Status),
+				"workgroup", aws.ToString(workgroup.WorkgroupName),
+				"status", workgroup.Status,
 			)
 			continue
 		}
 
 		tags := getRSSResourceTags(ctx, workgroup.WorkgroupArn, client, logger)
-		database, err := common.NewDatabaseFromRedshiftServerlessWorkgroup(workgroup, tags)
+
+		var database types.Database
+		if namespace, ok := namespaces[aws.ToString(workgroup.NamespaceName)]; ok {
+			database, err = common.NewDatabaseFromRedshiftServerlessWorkgroupWithNamespace(workgroup, tags, namespace)
+		} else {
+			database, err = common.NewDatabaseFromRedshiftServerlessWorkgroup(workgroup, tags)
+		}
 		if err != nil {
 			logger.InfoContext(ctx, "Could not convert Redshift Serverless workgroup to database resource",
-				"workgroup", aws.StringValue(workgroup.WorkgroupName),
+				"workgroup", aws.ToString(workgroup.WorkgroupName),
 				"error", err,
 			)
 			continue
@@ -121,7 +158,7 @@ func getDatabasesFromWorkgroups(ctx context.Context, client rssAPI, logger *slog
 	return databases, workgroupsWithTags, nil
 }
 
-func getDatabasesFromVPCEndpoints(ctx context.Context, workgroups []*workgroupWithTags, client rssAPI, logger *slog.Logger) (types.Databases, error) {
+func getDatabasesFromVPCEndpoints(ctx context.Context, workgroups []*workgroupWithTags, client rssClient, logger *slog.Logger) (types.Databases, error) {
 	endpoints, err := getRSSVPCEndpoints(ctx, client)
 	if err != nil {
 		return nil, trace.Wrap(err)
@@ -129,16 +166,16 @@ func getDatabasesFromVPCEndpoints(ctx context.Context, workgroups []*workgroupWi
 
 	var databases types.Databases
 	for _, endpoint := range endpoints {
-		workgroup, found := findWorkgroupWithName(workgroups, aws.StringValue(endpoint.WorkgroupName))
+		workgroup, found := findWorkgroupWithName(workgroups, aws.ToString(endpoint.WorkgroupName))
 		if !found {
-			logger.DebugContext(ctx, "Could not find matching workgroup for Redshift Serverless endpoint", "endpoint", aws.StringValue(endpoint.EndpointName))
+			logger.DebugContext(ctx, "Could not find matching workgroup for Redshift Serverless endpoint", "endpoint", aws.ToString(endpoint.EndpointName))
 			continue
 		}
 
-		if !libcloudaws.IsResourceAvailable(endpoint, endpoint.EndpointStatus) {
+		if !libcloudaws.IsResourceAvailable(endpoint, string(endpoint.EndpointStatus)) {
 			logger.DebugContext(ctx, "Skipping unavailable Redshift Serverless endpoint",
-				"endpoint", aws.StringValue(endpoint.EndpointName),
-				"status", aws.StringValue(endpoint.EndpointStatus),
+				"endpoint", aws.ToString(endpoint.EndpointName),
+				"status", endpoint.EndpointStatus,
 			)
 			continue
 		}
@@ -148,7 +185,7 @@ func getDatabasesFromVPCEndpoints(ctx context.Context, workgroups []*workgroupWi
 		database, err := common.NewDatabaseFromRedshiftServerlessVPCEndpoint(endpoint, workgroup.Workgroup, workgroup.Tags)
 		if err != nil {
 			logger.InfoContext(ctx, "Could not convert Redshift Serverless endpoint to database resource",
-				"endpoint", aws.StringValue(endpoint.EndpointName),
+				"endpoint", aws.ToString(endpoint.EndpointName),
 				"error", err,
 			)
 			continue
@@ -158,20 +195,20 @@ func getDatabasesFromVPCEndpoints(ctx context.Context, workgroups []*workgroupWi
 	return databases, nil
 }
 
-func getRSSResourceTags(ctx context.Context, arn *string, client rssAPI, logger *slog.Logger) []*redshiftserverless.Tag {
-	output, err := client.ListTagsForResourceWithContext(ctx, &redshiftserverless.ListTagsForResourceInput{
+func getRSSResourceTags(ctx context.Context, arn *string, client rssClient, logger *slog.Logger) []rsstypes.Tag {
+	output, err := client.ListTagsForResource(ctx, &redshiftserverless.ListTagsForResourceInput{
 		ResourceArn: arn,
 	})
 	if err != nil {
 		// Log errors here and return nil.
 		if trace.IsAccessDenied(err) {
 			logger.DebugContext(ctx, "No Permission to get Redshift Serverless tags",
-				"arn", aws.StringValue(arn),
+				"arn", aws.ToString(arn),
 				"error", err,
 			)
 		} else {
 			logger.WarnContext(ctx, "Failed to get Redshift Serverless tags",
-				"arn", aws.StringValue(arn),
+				"arn", aws.ToString(arn),
 				"error", err,
 			)
 		}
@@ -180,27 +217,190 @@ func getRSSResourceTags(ctx context.Context, arn *string, client rssAPI, logger
 	return output.Tags
 }
 
-func getRSSWorkgroups(ctx context.Context, client rssAPI) ([]*redshiftserverless.Workgroup, error) {
-	var pages [][]*redshiftserverless.Workgroup
-	err := client.ListWorkgroupsPagesWithContext(ctx, nil, func(page *redshiftserverless.ListWorkgroupsOutput, lastPage bool) bool {
-		pages = append(pages, page.Workgroups)
-		return len(pages) <= maxAWSPages
-	})
-	return flatten(pages), libcloudaws.ConvertRequestFailureError(err)
+func getRSSWorkgroups(ctx context.Context, client rssClient) ([]rsstypes.Workgroup, error) {
+	var workgroups []rsstypes.Workgroup
+	paginator := redshiftserverless.NewListWorkgroupsPaginator(client, &redshiftserverless.ListWorkgroupsInput{})
+	for pageCount := 0; paginator.HasMorePages() && pageCount <= maxAWSPages; pageCount++ {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, trace.Wrap(err)
+		}
+		workgroups = append(workgroups, page.Workgroups...)
+	}
+	return workgroups, nil
+}
+
+func getRSSVPCEndpoints(ctx context.Context, client rssClient) ([]rsstypes.EndpointAccess, error) {
+	var endpoints []rsstypes.EndpointAccess
+	paginator := redshiftserverless.NewListEndpointAccessPaginator(client, &redshiftserverless.ListEndpointAccessInput{})
+	for pageCount := 0; paginator.HasMorePages() && pageCount <= maxAWSPages; pageCount++ {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, trace.Wrap(err)
+		}
+		endpoints = append(endpoints, page.Endpoints...)
+	}
+	return endpoints, nil
+}
+
+// getRSSNamespacesByName fetches all Redshift Serverless namespaces and
+// returns them keyed by namespace name. AccessDenied is logged and treated
+// as "no namespaces" so a missing permission never fails the whole fetch.
+func getRSSNamespacesByName(ctx context.Context, client rssClient, logger *slog.Logger) map[string]rsstypes.Namespace {
+	namespaces, err := getRSSNamespaces(ctx, client)
+	if err != nil {
+		if trace.IsAccessDenied(err) {
+			logger.DebugContext(ctx, "No permission to get Redshift Serverless namespaces", "error", err)
+		} else {
+			logger.WarnContext(ctx, "Failed to get Redshift Serverless namespaces", "error", err)
+		}
+		return nil
+	}
+
+	byName := make(map[string]rsstypes.Namespace, len(namespaces))
+	for _, namespace := range namespaces {
+		byName[aws.ToString(namespace.NamespaceName)] = namespace
+	}
+	return byName
+}
+
+func getRSSNamespaces(ctx context.Context, client rssClient) ([]rsstypes.Namespace, error) {
+	var namespaces []rsstypes.Namespace
+	paginator := redshiftserverless.NewListNamespacesPaginator(client, &redshiftserverless.ListNamespacesInput{})
+	for pageCount := 0; paginator.HasMorePages() && pageCount <= maxAWSPages; pageCount++ {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, trace.Wrap(err)
+		}
+		namespaces = append(namespaces, page.Namespaces...)
+	}
+	return namespaces, nil
+}
+
+// applyRSSSnapshotLabels attaches a label listing the Redshift Serverless
+// snapshots taken of each workgroup's namespace onto the matching database.
+// AccessDenied is logged and skipped, never failing the fetch.
+func applyRSSSnapshotLabels(ctx context.Context, client rssClient, databases types.Databases, workgroups []*workgroupWithTags, logger *slog.Logger) {
+	snapshotsByNamespace, err := getRSSSnapshotNamesByNamespace(ctx, client)
+	if err != nil {
+		if trace.IsAccessDenied(err) {
+			logger.DebugContext(ctx, "No permission to get Redshift Serverless snapshots", "error", err)
+		} else {
+			logger.WarnContext(ctx, "Failed to get Redshift Serverless snapshots", "error", err)
+		}
+		return
+	}
+
+	namespaceByWorkgroup := make(map[string]string, len(workgroups))
+	for _, workgroup := range workgroups {
+		namespaceByWorkgroup[aws.ToString(workgroup.WorkgroupName)] = aws.ToString(workgroup.NamespaceName)
+	}
+
+	for _, database := range databases {
+		namespaceName, ok := namespaceByWorkgroup[database.GetName()]
+		if !ok {
+			continue
+		}
+		if snapshots := snapshotsByNamespace[namespaceName]; len(snapshots) > 0 {
+			addDatabaseLabel(database, redshiftServerlessLabelSnapshots, strings.Join(snapshots, ","))
+		}
+	}
 }
 
-func getRSSVPCEndpoints(ctx context.Context, client rssAPI) ([]*redshiftserverless.EndpointAccess, error) {
-	var pages [][]*redshiftserverless.EndpointAccess
-	err := client.ListEndpointAccessPagesWithContext(ctx, nil, func(page *redshiftserverless.ListEndpointAccessOutput, lastPage bool) bool {
-		pages = append(pages, page.Endpoints)
-		return len(pages) <= maxAWSPages
+func getRSSSnapshotNamesByNamespace(ctx context.Context, client rssClient) (map[string][]string, error) {
+	snapshots, err := getRSSSnapshots(ctx, client)
+	if err != nil {
+		return nil, trace.Wrap(err)
+	}
+
+	byNamespace := make(map[string][]string)
+	for _, snapshot := range snapshots {
+		namespaceName := aws.ToString(snapshot.NamespaceName)
+		byNamespace[namespaceName] = append(byNamespace[namespaceName], aws.ToString(snapshot.SnapshotName))
+	}
+	return byNamespace, nil
+}
+
+func getRSSSnapshots(ctx context.Context, client rssClient) ([]rsstypes.Snapshot, error) {
+	var snapshots []rsstypes.Snapshot
+	paginator := redshiftserverless.NewListSnapshotsPaginator(client, &redshiftserverless.ListSnapshotsInput{})
+	for pageCount := 0; paginator.HasMorePages() && pageCount <= maxAWSPages; pageCount++ {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, trace.Wrap(err)
+		}
+		snapshots = append(snapshots, page.Snapshots...)
+	}
+	return snapshots, nil
+}
+
+// applyRSSUsageLimitLabels attaches a label listing the usage limit IDs
+// configured for each workgroup onto the matching database. Usage limits are
+// fetched per workgroup, so an AccessDenied on one workgroup is logged and
+// skipped without affecting the others.
+func applyRSSUsageLimitLabels(ctx context.Context, client rssClient, databases types.Databases, workgroups []*workgroupWithTags, logger *slog.Logger) {
+	databasesByName := make(map[string]types.Database, len(databases))
+	for _, database := range databases {
+		databasesByName[database.GetName()] = database
+	}
+
+	for _, workgroup := range workgroups {
+		database, ok := databasesByName[aws.ToString(workgroup.WorkgroupName)]
+		if !ok {
+			continue
+		}
+
+		limitIDs, err := getRSSUsageLimitIDs(ctx, client, workgroup.WorkgroupArn)
+		if err != nil {
+			if trace.IsAccessDenied(err) {
+				logger.DebugContext(ctx, "No permission to get Redshift Serverless usage limits",
+					"workgroup", aws.ToString(workgroup.WorkgroupName),
+					"error", err,
+				)
+			} else {
+				logger.WarnContext(ctx, "Failed to get Redshift Serverless usage limits",
+					"workgroup", aws.ToString(workgroup.WorkgroupName),
+					"error", err,
+				)
+			}
+			continue
+		}
+		if len(limitIDs) > 0 {
+			addDatabaseLabel(database, redshiftServerlessLabelUsageLimits, strings.Join(limitIDs, ","))
+		}
+	}
+}
+
+func getRSSUsageLimitIDs(ctx context.Context, client rssClient, resourceArn *string) ([]string, error) {
+	var ids []string
+	paginator := redshiftserverless.NewListUsageLimitsPaginator(client, &redshiftserverless.ListUsageLimitsInput{
+		ResourceArn: resourceArn,
 	})
-	return flatten(pages), libcloudaws.ConvertRequestFailureError(err)
+	for pageCount := 0; paginator.HasMorePages() && pageCount <= maxAWSPages; pageCount++ {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, trace.Wrap(err)
+		}
+		for _, limit := range page.UsageLimits {
+			ids = append(ids, aws.ToString(limit.UsageLimitId))
+		}
+	}
+	return ids, nil
+}
+
+// addDatabaseLabel merges key/value into database's static labels.
+func addDatabaseLabel(database types.Database, key, value string) {
+	labels := database.GetStaticLabels()
+	if labels == nil {
+		labels = make(map[string]string, 1)
+	}
+	labels[key] = value
+	database.SetStaticLabels(labels)
 }
 
 func findWorkgroupWithName(workgroups []*workgroupWithTags, name string) (*workgroupWithTags, bool) {
 	for _, workgroup := range workgroups {
-		if aws.StringValue(workgroup.WorkgroupName) == name {
+		if aws.ToString(workgroup.WorkgroupName) == name {
 			return workgroup, true
 		}
 	}